@@ -0,0 +1,82 @@
+// Copyright © 2018 Timothy E. Peoples <eng@toolman.org>
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package errbag
+
+import (
+	"toolman.org/base/errors/errbag/coded"
+)
+
+// defaultCodespace is the codespace returned by Code when eb contains no
+// error registered via coded.Register.
+const defaultCodespace = "undefined"
+
+// Code walks eb, using Unwrap, looking for the first error registered via
+// coded.Register and returns its ABCI-style (codespace, code, log) triple --
+// giving gRPC/HTTP handlers a one-call way to translate an aggregated
+// ErrorBag into a stable status code. If no registered error is found, Code
+// returns (defaultCodespace, 1, eb's full multi-error rendering).
+//
+// debug controls the verbosity of log: when true, log is eb's full
+// multi-error rendering -- every contained error's message, not just a
+// count -- so internal detail stays available to debug builds; when
+// false, it's just the matched error's top-level description. This
+// mirrors the debug/non-debug split of the external ABCI implementation
+// this is modeled on.
+func (eb *ErrorBag) Code(debug bool) (codespace string, code uint32, log string) {
+	if ce := findCoded(eb); ce != nil {
+		if debug {
+			return ce.Codespace, ce.Code, eb.fullRender()
+		}
+		return ce.Codespace, ce.Code, ce.Description
+	}
+
+	return defaultCodespace, 1, eb.fullRender()
+}
+
+// fullRender renders every error contained in eb, regardless of any
+// Formatter configured on eb, so debug logging always sees the underlying
+// detail that the non-debug path deliberately hides.
+func (eb *ErrorBag) fullRender() string {
+	return JoinFormatter{}.Format(eb.errs)
+}
+
+// findCoded performs the same pre-order depth-first search that
+// errors.Is/errors.As use (via Unwrap), looking for the first error that is
+// both a *coded.CodedError and was actually returned by coded.Register --
+// as opposed to a hand-built &coded.CodedError{} that merely shares its
+// exported fields with a registered one.
+func findCoded(err error) *coded.CodedError {
+	if err == nil {
+		return nil
+	}
+
+	if ce, ok := err.(*coded.CodedError); ok && coded.Registered(ce) {
+		return ce
+	}
+
+	switch x := err.(type) {
+	case interface{ Unwrap() error }:
+		return findCoded(x.Unwrap())
+	case interface{ Unwrap() []error }:
+		for _, e := range x.Unwrap() {
+			if ce := findCoded(e); ce != nil {
+				return ce
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright © 2018 Timothy E. Peoples <eng@toolman.org>
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+// Package coded provides registered sentinel errors identified by an
+// ABCI-style (codespace, code) pair, modeled on the cosmos-sdk errors
+// package's ABCIInfo pattern. It's a sibling of errbag rather than a part
+// of it so that callers who only want coded sentinels aren't forced to
+// pull in the rest of the multi-error collection machinery.
+package coded
+
+import "fmt"
+
+// CodedError is a registered sentinel error carrying a codespace, a
+// numeric code and a human-readable description. Register a CodedError
+// once at package init time and compare against it later with errors.Is.
+type CodedError struct {
+	Codespace   string
+	Code        uint32
+	Description string
+}
+
+// Error implements the error interface.
+func (e *CodedError) Error() string {
+	return fmt.Sprintf("%s: %s (code %d)", e.Codespace, e.Description, e.Code)
+}
+
+// Is implements the errors.Is interface. Two CodedErrors are considered
+// equal when they share the same codespace and code, regardless of
+// pointer identity or description -- mirroring cosmos-sdk's comparison by
+// (codespace, code) rather than by value or identity.
+func (e *CodedError) Is(target error) bool {
+	t, ok := target.(*CodedError)
+	if !ok {
+		return false
+	}
+	return e.Codespace == t.Codespace && e.Code == t.Code
+}
+
+var registry = map[string]map[uint32]*CodedError{}
+
+// Register creates a new CodedError under the given codespace and code and
+// records it so it can later be recognized by errbag's ErrorBag.Code.
+// Register panics if codespace/code has already been registered; like
+// cosmos-sdk's error codes, these are meant to be declared once, at
+// package init time, not computed at runtime.
+func Register(codespace string, code uint32, description string) *CodedError {
+	codes, ok := registry[codespace]
+	if !ok {
+		codes = map[uint32]*CodedError{}
+		registry[codespace] = codes
+	}
+
+	if _, dup := codes[code]; dup {
+		panic(fmt.Sprintf("coded: code %d already registered for codespace %q", code, codespace))
+	}
+
+	ce := &CodedError{Codespace: codespace, Code: code, Description: description}
+	codes[code] = ce
+	return ce
+}
+
+// Registered reports whether ce is exactly the *CodedError instance
+// returned by a prior call to Register for its codespace and code. This
+// distinguishes a genuinely registered sentinel from a hand-built
+// &CodedError{...} with the same exported fields, which was never passed
+// to Register.
+func Registered(ce *CodedError) bool {
+	if ce == nil {
+		return false
+	}
+	return registry[ce.Codespace][ce.Code] == ce
+}
@@ -0,0 +1,69 @@
+// Copyright © 2018 Timothy E. Peoples <eng@toolman.org>
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package coded
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestRegisterIs(t *testing.T) {
+	ce := Register("TestRegisterIs", 42, "something went wrong")
+
+	if !errors.Is(ce, ce) {
+		t.Error("errors.Is(ce, ce) := false; wanted true")
+	}
+
+	other := &CodedError{Codespace: "TestRegisterIs", Code: 42, Description: "a different description"}
+	if !errors.Is(ce, other) {
+		t.Error("errors.Is(ce, other) := false; wanted true for matching codespace/code")
+	}
+
+	wrapped := fmt.Errorf("calling widget: %w", ce)
+	if !errors.Is(wrapped, ce) {
+		t.Error("errors.Is(wrapped, ce) := false; wanted true")
+	}
+}
+
+func TestRegisterDuplicatePanics(t *testing.T) {
+	Register("TestRegisterDuplicatePanics", 1, "first")
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Register with a duplicate code did not panic")
+		}
+	}()
+
+	Register("TestRegisterDuplicatePanics", 1, "second")
+}
+
+func TestRegistered(t *testing.T) {
+	ce := Register("TestRegistered", 1, "something went wrong")
+
+	if !Registered(ce) {
+		t.Error("Registered(ce) := false; wanted true for a value returned by Register")
+	}
+
+	fake := &CodedError{Codespace: "TestRegistered", Code: 1, Description: "something went wrong"}
+	if Registered(fake) {
+		t.Error("Registered(fake) := true; wanted false for a hand-built CodedError that was never Registered")
+	}
+
+	if Registered(nil) {
+		t.Error("Registered(nil) := true; wanted false")
+	}
+}
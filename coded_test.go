@@ -0,0 +1,81 @@
+// Copyright © 2018 Timothy E. Peoples <eng@toolman.org>
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package errbag
+
+import (
+	"testing"
+
+	"toolman.org/base/errors/errbag/coded"
+)
+
+func TestErrorBagCodeNoMatch(t *testing.T) {
+	eb := new(ErrorBag)
+	eb.Errorf("first error")
+	eb.Errorf("second error")
+	eb.Errorf("third error")
+
+	codespace, code, log := eb.Code(false)
+	if codespace != defaultCodespace || code != 1 {
+		t.Errorf("Code(false) := (%q, %d, ...); wanted (%q, 1, ...)", codespace, code, defaultCodespace)
+	}
+
+	want := "first error\nsecond error\nthird error"
+	if log != want {
+		t.Errorf("log := %q; wanted the full render %q, not a lossy summary", log, want)
+	}
+
+	if _, _, log := eb.Code(true); log != want {
+		t.Errorf("debug log := %q; wanted the full render %q", log, want)
+	}
+}
+
+func TestErrorBagCodeMatch(t *testing.T) {
+	ce := coded.Register("TestErrorBagCodeMatch", 7, "widget exploded")
+
+	eb := new(ErrorBag)
+	eb.Errorf("unrelated error")
+	eb.Add(ce)
+
+	codespace, code, log := eb.Code(false)
+	if codespace != "TestErrorBagCodeMatch" || code != 7 {
+		t.Errorf("Code(false) := (%q, %d, ...); wanted (%q, 7, ...)", codespace, code, "TestErrorBagCodeMatch")
+	}
+	if log != "widget exploded" {
+		t.Errorf("non-debug log := %q; wanted description %q", log, "widget exploded")
+	}
+
+	want := "unrelated error\nTestErrorBagCodeMatch: widget exploded (code 7)"
+	if _, _, log := eb.Code(true); log != want {
+		t.Errorf("debug log := %q; wanted the full render %q", log, want)
+	}
+}
+
+func TestErrorBagCodeIgnoresUnregisteredCodedError(t *testing.T) {
+	coded.Register("TestErrorBagCodeIgnoresUnregisteredCodedError", 1, "the real one")
+	fake := &coded.CodedError{
+		Codespace:   "TestErrorBagCodeIgnoresUnregisteredCodedError",
+		Code:        1,
+		Description: "never registered",
+	}
+
+	eb := new(ErrorBag)
+	eb.Add(fake)
+
+	codespace, code, _ := eb.Code(false)
+	if codespace != defaultCodespace || code != 1 {
+		t.Errorf("Code(false) := (%q, %d, ...); wanted the default (%q, 1, ...) since fake was never Registered", codespace, code, defaultCodespace)
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright © 2018 Timothy E. Peoples <eng@toolman.org>
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package errbag
+
+import (
+	"context"
+	"sync"
+)
+
+// SafeErrorBag is an ErrorBag that's safe for concurrent use: Add, Errorf,
+// Wrap, Merge, Errors and Visit are all guarded by a mutex. It also adds
+// Go and GoCtx, an errgroup-like way to launch goroutines whose errors are
+// collected automatically, while keeping the full multi-error collection
+// semantics of ErrorBag rather than only reporting the first failure.
+type SafeErrorBag struct {
+	ErrorBag
+	mu       sync.Mutex
+	wg       sync.WaitGroup
+	failFast bool
+
+	ctxOnce sync.Once
+	ctx     context.Context
+	cancel  context.CancelFunc
+}
+
+// WithMutex returns a new *SafeErrorBag, ready for concurrent use.
+func WithMutex() *SafeErrorBag {
+	return new(SafeErrorBag)
+}
+
+// FailFast marks seb so that GoCtx cancels its derived context as soon as
+// any launched function returns a non-nil error -- giving errgroup-like
+// fail-fast behavior while still collecting every error that arrives
+// before cancellation takes effect. FailFast returns seb for chaining.
+func (seb *SafeErrorBag) FailFast() *SafeErrorBag {
+	seb.failFast = true
+	return seb
+}
+
+// Add is Add guarded by seb's mutex.
+func (seb *SafeErrorBag) Add(err error, errors ...interface{}) error {
+	seb.mu.Lock()
+	defer seb.mu.Unlock()
+	return seb.ErrorBag.Add(err, errors...)
+}
+
+// Errorf is Errorf guarded by seb's mutex.
+func (seb *SafeErrorBag) Errorf(msg string, a ...interface{}) error {
+	seb.mu.Lock()
+	defer seb.mu.Unlock()
+	return seb.ErrorBag.Errorf(msg, a...)
+}
+
+// Wrap is Wrap guarded by seb's mutex.
+func (seb *SafeErrorBag) Wrap(err error) error {
+	seb.mu.Lock()
+	defer seb.mu.Unlock()
+	return seb.ErrorBag.Wrap(err)
+}
+
+// Merge is Merge guarded by seb's mutex.
+func (seb *SafeErrorBag) Merge(oeb *ErrorBag) error {
+	seb.mu.Lock()
+	defer seb.mu.Unlock()
+	return seb.ErrorBag.Merge(oeb)
+}
+
+// Errors returns a snapshot of the errors currently contained in seb,
+// taken under its mutex.
+func (seb *SafeErrorBag) Errors() []error {
+	seb.mu.Lock()
+	defer seb.mu.Unlock()
+
+	errs := make([]error, len(seb.errs))
+	copy(errs, seb.errs)
+	return errs
+}
+
+// Visit executes v for each error currently in seb. The errors are
+// snapshotted under seb's mutex before v is called for any of them, so a
+// Visitor that calls back into seb (e.g. via Add) can't deadlock.
+func (seb *SafeErrorBag) Visit(v Visitor) {
+	for _, err := range seb.Errors() {
+		v(err)
+	}
+}
+
+// Go launches fn in a new goroutine, tracking it in seb's internal
+// WaitGroup, and adds fn's return value to seb once it completes (if
+// non-nil). Call Wait to block until every goroutine launched via Go or
+// GoCtx has finished.
+func (seb *SafeErrorBag) Go(fn func() error) {
+	seb.wg.Add(1)
+	go func() {
+		defer seb.wg.Done()
+		if err := fn(); err != nil {
+			seb.Add(err)
+		}
+	}()
+}
+
+// GoCtx is Go for functions that take a context.Context, derived from ctx
+// and shared across every GoCtx call on seb. If seb was constructed with
+// FailFast, that derived context is canceled as soon as any launched fn
+// returns a non-nil error.
+func (seb *SafeErrorBag) GoCtx(ctx context.Context, fn func(context.Context) error) {
+	derived := seb.deriveContext(ctx)
+
+	seb.wg.Add(1)
+	go func() {
+		defer seb.wg.Done()
+		if err := fn(derived); err != nil {
+			seb.Add(err)
+			if seb.failFast {
+				seb.cancel()
+			}
+		}
+	}()
+}
+
+func (seb *SafeErrorBag) deriveContext(parent context.Context) context.Context {
+	seb.ctxOnce.Do(func() {
+		seb.ctx, seb.cancel = context.WithCancel(parent)
+	})
+	return seb.ctx
+}
+
+// Wait blocks until every goroutine launched via Go or GoCtx has finished,
+// then returns seb.ErrorOrNil(). If GoCtx ever derived a context, Wait
+// cancels it so its registration in the parent context is released even
+// when FailFast never triggered.
+func (seb *SafeErrorBag) Wait() error {
+	seb.wg.Wait()
+	if seb.cancel != nil {
+		seb.cancel()
+	}
+	return seb.ErrorOrNil()
+}
@@ -0,0 +1,82 @@
+// Copyright © 2018 Timothy E. Peoples <eng@toolman.org>
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package errbag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestSafeErrorBagGo(t *testing.T) {
+	seb := WithMutex()
+
+	for i := 0; i < 10; i++ {
+		i := i
+		seb.Go(func() error {
+			if i%2 == 0 {
+				return nil
+			}
+			return fmt.Errorf("error %d", i)
+		})
+	}
+
+	err := seb.Wait()
+	if err == nil {
+		t.Fatal("Wait() := nil; wanted a non-nil error")
+	}
+
+	if got := AsErrorBag(err).Size(); got != 5 {
+		t.Errorf("collected %d errors; wanted 5", got)
+	}
+}
+
+func TestSafeErrorBagGoCtxFailFast(t *testing.T) {
+	seb := WithMutex().FailFast()
+
+	boom := errors.New("boom")
+	ctx := context.Background()
+
+	seb.GoCtx(ctx, func(ctx context.Context) error {
+		return boom
+	})
+
+	seb.GoCtx(ctx, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	if err := seb.Wait(); !errors.Is(err, boom) {
+		t.Errorf("Wait() := %v; wanted it to wrap %v", err, boom)
+	}
+}
+
+func TestSafeErrorBagVisitSnapshot(t *testing.T) {
+	seb := WithMutex()
+	seb.Errorf("first error")
+	seb.Errorf("second error")
+
+	var visited int
+	seb.Visit(func(err error) {
+		visited++
+		seb.Errorf("added during visit: %s", err)
+	})
+
+	if visited != 2 {
+		t.Errorf("visited %d errors; wanted 2 (a snapshot taken before Visit's callback ran)", visited)
+	}
+}
@@ -28,9 +28,11 @@ import (
 // cannot catch all cases; e.g. a call to its Errorf method with the current
 // ErrorBag instance as one of the interface parameters is not easily detected.
 type ErrorBag struct {
-	errs    []error
-	wrapper ErrorWrapper
-	defers  []ErrorFunc
+	errs      []error
+	wrapper   ErrorWrapper
+	defers    []ErrorFunc
+	trace     bool
+	formatter Formatter
 }
 
 // ErrorFunc is a function that takes no arguments and returns an error.
@@ -86,19 +88,35 @@ func (eb *ErrorBag) stash(items ...interface{}) {
 	}
 }
 
-// Error implements the error interface for the ErrorBag eb. If eb contains
-// only 1 error, the the results of that error's Error method are returned.
-// If eb contains more then 1 error, then a message is returned indicating
-// how many errors it encounted; the caller should use Errors or Visit to
-// access the contained errors.  When eb contains no errors an empty string
-// is returned.
+// Error implements the error interface for the ErrorBag eb. If a Formatter
+// has been installed (via WithFormatter, the Formatter method, or
+// SetDefaultFormatter) it's used to render all of eb's errors, even if
+// there's only one. Otherwise, if eb contains only 1 error, the results of
+// that error's Error method are returned. If eb contains more than 1 error,
+// then a message is returned indicating how many errors it encounted; the
+// caller should use Errors or Visit to access the contained errors. When eb
+// contains no errors an empty string is returned.
 func (eb *ErrorBag) Error() string {
-	if l := len(eb.errs); l == 1 {
+	l := len(eb.errs)
+	if l == 0 {
+		return ""
+	}
+
+	if f := eb.formatterOrDefault(); f != nil {
+		return f.Format(eb.errs)
+	}
+
+	if l == 1 {
 		return eb.errs[0].Error()
-	} else if l > 1 {
-		return fmt.Sprintf("encountered %d errors", l)
 	}
-	return ""
+	return fmt.Sprintf("encountered %d errors", l)
+}
+
+func (eb *ErrorBag) formatterOrDefault() Formatter {
+	if eb.formatter != nil {
+		return eb.formatter
+	}
+	return defaultFormatter
 }
 
 // Errors returns the slice of errors currently contained in the ErrorBag eb.
@@ -106,6 +124,24 @@ func (eb *ErrorBag) Errors() []error {
 	return []error(eb.errs)
 }
 
+// Unwrap returns the errors contained in the ErrorBag eb so that the standard
+// library's errors.Is and errors.As can perform a pre-order depth-first
+// search across every error collected here (and recurse into any wrapped
+// or joined children, per Go 1.20's multi-error Unwrap semantics).
+//
+// If eb contains no errors, Unwrap returns nil rather than an empty slice so
+// that errors.Is/errors.As won't bother recursing into an empty bag. The
+// returned slice is a defensive copy; mutating it has no effect on eb.
+func (eb *ErrorBag) Unwrap() []error {
+	if len(eb.errs) == 0 {
+		return nil
+	}
+
+	errs := make([]error, len(eb.errs))
+	copy(errs, eb.errs)
+	return errs
+}
+
 // Sorted returns the errors contained in the ErrorBag eb sorted lexically.
 func (eb *ErrorBag) Sorted() []error {
 	errs := eb.Errors()
@@ -223,10 +259,32 @@ func (eb *ErrorBag) add(err error) error {
 		return eb.Merge(oeb)
 	}
 
+	if eb.trace {
+		err = newTracedError(err)
+	}
+
 	eb.errs = append(eb.errs, err)
 	return eb
 }
 
+// WithStackTrace returns a new *ErrorBag that captures a stack trace at the
+// point each error is collected via Add, Errorf, Wrap or stash. The frames
+// for a given error can be retrieved with the package-level Frames func,
+// and formatting the bag with "%+v" renders every contained error followed
+// by its stack. Tracing adds no overhead when left disabled (the default).
+func WithStackTrace() *ErrorBag {
+	return &ErrorBag{trace: true}
+}
+
+// TraceOnAdd toggles stack-trace capture on eb. Passing true causes
+// subsequent calls to Add, Errorf, Wrap and stash to capture a stack trace
+// for each newly collected error; passing false disables it again. This is
+// useful for enabling tracing on an ErrorBag embedded in another type,
+// where the WithStackTrace constructor can't be used directly.
+func (eb *ErrorBag) TraceOnAdd(trace bool) {
+	eb.trace = trace
+}
+
 func (eb *ErrorBag) Merge(oeb *ErrorBag) error {
 	if oeb == nil {
 		return eb
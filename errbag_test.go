@@ -18,6 +18,8 @@ package errbag
 import (
 	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"reflect"
 	"strings"
 	"testing"
@@ -189,6 +191,62 @@ func (eb *ErrorBag) GoString() string {
 
 //----------------------------------------------------------------------------
 
+func TestErrorBagUnwrap(t *testing.T) {
+	if eb := new(ErrorBag); eb.Unwrap() != nil {
+		t.Errorf("empty ErrorBag.Unwrap() := %#v; wanted nil", eb.Unwrap())
+	}
+
+	eb := new(ErrorBag)
+	eb.Errorf("first error")
+	eb.Errorf("second error")
+
+	unwrapped := eb.Unwrap()
+	if len(unwrapped) != 2 {
+		t.Fatalf("Unwrap() := %#v; wanted 2 errors", unwrapped)
+	}
+
+	unwrapped[0] = nil
+	if eb.errs[0] == nil {
+		t.Error("mutating the slice returned by Unwrap also mutated eb; wanted a defensive copy")
+	}
+}
+
+func TestErrorBagUnwrapIs(t *testing.T) {
+	eb := new(ErrorBag)
+	eb.Errorf("reading config: %w", io.EOF)
+	eb.Errorf("unrelated error")
+
+	if !errors.Is(eb, io.EOF) {
+		t.Error("errors.Is(eb, io.EOF) := false; wanted true")
+	}
+
+	if errors.Is(eb, io.ErrClosedPipe) {
+		t.Error("errors.Is(eb, io.ErrClosedPipe) := true; wanted false")
+	}
+}
+
+func TestErrorBagUnwrapAs(t *testing.T) {
+	pe := &fs.PathError{Op: "open", Path: "/nope", Err: io.EOF}
+
+	inner := new(ErrorBag)
+	inner.Add(fmt.Errorf("opening file: %w", pe))
+
+	outer := new(ErrorBag)
+	outer.Errorf("unrelated error")
+	outer.Add(fmt.Errorf("nested: %w", inner))
+
+	var got *fs.PathError
+	if !errors.As(outer, &got) {
+		t.Fatal("errors.As(outer, &got) := false; wanted true")
+	}
+
+	if got != pe {
+		t.Errorf("errors.As found %#v; wanted %#v", got, pe)
+	}
+}
+
+//----------------------------------------------------------------------------
+
 type newTestcase struct {
 	err    error
 	others []interface{}
@@ -0,0 +1,126 @@
+// Copyright © 2018 Timothy E. Peoples <eng@toolman.org>
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package errbag
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Formatter renders the errors collected in an ErrorBag into a single
+// string for use by Error. Install one with WithFormatter or the Formatter
+// method to replace the default "encountered N errors" summary, or with
+// SetDefaultFormatter to change the package-wide default.
+type Formatter interface {
+	Format(errs []error) string
+}
+
+// FormatterFunc adapts a plain function to the Formatter interface.
+type FormatterFunc func(errs []error) string
+
+// Format implements Formatter.
+func (f FormatterFunc) Format(errs []error) string { return f(errs) }
+
+// BulletFormatter renders one line per error, each prefixed with "* ", in
+// the style of hashicorp/go-multierror.
+type BulletFormatter struct{}
+
+// Format implements Formatter.
+func (BulletFormatter) Format(errs []error) string {
+	lines := make([]string, len(errs))
+	for i, err := range errs {
+		lines[i] = "* " + err.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// JoinFormatter renders one line per error with no prefix, matching the
+// layout produced by the standard library's errors.Join.
+type JoinFormatter struct{}
+
+// Format implements Formatter.
+func (JoinFormatter) Format(errs []error) string {
+	lines := make([]string, len(errs))
+	for i, err := range errs {
+		lines[i] = err.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// JSONFormatter renders the collected errors as a JSON object suitable for
+// structured logging pipelines (e.g. zap, slog):
+//
+//	{"errors":[{"msg":"...","type":"..."}]}
+type JSONFormatter struct{}
+
+// Format implements Formatter. If the JSON encoding fails, Format falls
+// back to a message describing the failure rather than panicking.
+func (JSONFormatter) Format(errs []error) string {
+	b, err := json.Marshal(jsonErrors(errs))
+	if err != nil {
+		return fmt.Sprintf(`{"errors":[{"msg":%q,"type":"errbag.JSONFormatter"}]}`, err.Error())
+	}
+	return string(b)
+}
+
+type jsonError struct {
+	Msg  string `json:"msg"`
+	Type string `json:"type"`
+}
+
+type jsonErrorList struct {
+	Errors []jsonError `json:"errors"`
+}
+
+func jsonErrors(errs []error) jsonErrorList {
+	list := jsonErrorList{Errors: make([]jsonError, len(errs))}
+	for i, err := range errs {
+		list.Errors[i] = jsonError{Msg: err.Error(), Type: fmt.Sprintf("%T", err)}
+	}
+	return list
+}
+
+// defaultFormatter is used by any ErrorBag that hasn't been given its own
+// Formatter; nil preserves the original "encountered N errors" summary.
+var defaultFormatter Formatter
+
+// SetDefaultFormatter installs formatter as the package-wide default used
+// by any ErrorBag that hasn't been given its own via WithFormatter or the
+// Formatter method. Passing nil restores the original summary.
+func SetDefaultFormatter(formatter Formatter) {
+	defaultFormatter = formatter
+}
+
+// WithFormatter returns a new *ErrorBag that uses formatter to render its
+// Error string.
+func WithFormatter(formatter Formatter) *ErrorBag {
+	return &ErrorBag{formatter: formatter}
+}
+
+// Formatter installs formatter into an existing ErrorBag eb; subsequent
+// calls to Error will use it. Passing nil reverts eb to the package
+// default (or the built-in summary, if none is set).
+func (eb *ErrorBag) Formatter(formatter Formatter) {
+	eb.formatter = formatter
+}
+
+// MarshalJSON implements json.Marshaler using JSONFormatter, regardless of
+// any Formatter configured on eb, so ErrorBag values drop cleanly into
+// zap/slog records and other structured logging pipelines.
+func (eb *ErrorBag) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonErrors(eb.errs))
+}
@@ -0,0 +1,108 @@
+// Copyright © 2018 Timothy E. Peoples <eng@toolman.org>
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package errbag
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestBulletFormatter(t *testing.T) {
+	eb := WithFormatter(BulletFormatter{})
+	eb.Errorf("first error")
+	eb.Errorf("second error")
+
+	want := "* first error\n* second error"
+	if got := eb.Error(); got != want {
+		t.Errorf("Error() := %q; wanted %q", got, want)
+	}
+}
+
+func TestJoinFormatter(t *testing.T) {
+	eb := WithFormatter(JoinFormatter{})
+	eb.Errorf("first error")
+	eb.Errorf("second error")
+
+	want := "first error\nsecond error"
+	if got := eb.Error(); got != want {
+		t.Errorf("Error() := %q; wanted %q", got, want)
+	}
+}
+
+func TestJSONFormatter(t *testing.T) {
+	eb := WithFormatter(JSONFormatter{})
+	eb.Errorf("boom")
+
+	var got jsonErrorList
+	if err := json.Unmarshal([]byte(eb.Error()), &got); err != nil {
+		t.Fatalf("json.Unmarshal(%q): %v", eb.Error(), err)
+	}
+
+	if len(got.Errors) != 1 || got.Errors[0].Msg != "boom" {
+		t.Errorf("Error() := %q; wanted a single error with msg %q", eb.Error(), "boom")
+	}
+}
+
+func TestErrorBagMarshalJSON(t *testing.T) {
+	eb := new(ErrorBag)
+	eb.Errorf("first error")
+	eb.Errorf("second error")
+
+	b, err := json.Marshal(eb)
+	if err != nil {
+		t.Fatalf("json.Marshal(eb): %v", err)
+	}
+
+	var got jsonErrorList
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("json.Unmarshal(%q): %v", b, err)
+	}
+
+	if len(got.Errors) != 2 {
+		t.Fatalf("got %d errors; wanted 2", len(got.Errors))
+	}
+	if got.Errors[0].Msg != "first error" || got.Errors[1].Msg != "second error" {
+		t.Errorf("unexpected errors: %+v", got.Errors)
+	}
+}
+
+func TestSetDefaultFormatter(t *testing.T) {
+	SetDefaultFormatter(BulletFormatter{})
+	defer SetDefaultFormatter(nil)
+
+	eb := new(ErrorBag)
+	eb.Errorf("first error")
+	eb.Errorf("second error")
+
+	want := "* first error\n* second error"
+	if got := eb.Error(); got != want {
+		t.Errorf("Error() := %q; wanted %q", got, want)
+	}
+}
+
+func TestErrorDefaultUnchangedWithoutFormatter(t *testing.T) {
+	eb := new(ErrorBag)
+	eb.Errorf("only error")
+
+	if got := eb.Error(); got != "only error" {
+		t.Errorf("Error() := %q; wanted %q", got, "only error")
+	}
+
+	eb.Errorf("second error")
+	if got := eb.Error(); got != "encountered 2 errors" {
+		t.Errorf("Error() := %q; wanted %q", got, "encountered 2 errors")
+	}
+}
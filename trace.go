@@ -0,0 +1,129 @@
+// Copyright © 2018 Timothy E. Peoples <eng@toolman.org>
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package errbag
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+)
+
+// stackDepth bounds how many program counters are captured per traced
+// error; this mirrors the juju/errors and pkg/errors convention of a fixed,
+// generous ceiling rather than an unbounded walk.
+const stackDepth = 32
+
+// tracedError wraps an error collected while stack-trace capture is enabled
+// on its ErrorBag (see WithStackTrace and TraceOnAdd). The stack is captured
+// as a cheap []uintptr at Add time and only resolved into runtime.Frames
+// lazily, on first use by Frames or "%+v" formatting.
+type tracedError struct {
+	err    error
+	pcs    []uintptr
+	frames []runtime.Frame
+}
+
+// newTracedError captures the caller's stack and wraps err for later
+// retrieval via Frames. If err is already traced, it's returned as is
+// so merging two traced bags doesn't stack traces on top of traces.
+func newTracedError(err error) error {
+	if _, ok := err.(*tracedError); ok {
+		return err
+	}
+
+	var pcs [stackDepth]uintptr
+	n := runtime.Callers(3, pcs[:])
+	return &tracedError{err: err, pcs: pcs[:n]}
+}
+
+// Error implements the error interface by delegating to the wrapped error.
+func (te *tracedError) Error() string { return te.err.Error() }
+
+// Unwrap returns the wrapped error so errors.Is and errors.As see through
+// tracedError to whatever it's carrying.
+func (te *tracedError) Unwrap() error { return te.err }
+
+// resolve lazily expands the captured program counters into runtime.Frames.
+func (te *tracedError) resolve() []runtime.Frame {
+	if te.frames == nil && len(te.pcs) > 0 {
+		frames := runtime.CallersFrames(te.pcs)
+		for {
+			frame, more := frames.Next()
+			te.frames = append(te.frames, frame)
+			if !more {
+				break
+			}
+		}
+	}
+	return te.frames
+}
+
+// Format implements fmt.Formatter. "%v" and "%s" print the wrapped error's
+// message exactly as Error() would; "%+v" additionally prints an indented
+// stack trace of "file:line function" entries beneath it.
+func (te *tracedError) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		io.WriteString(f, te.Error())
+		if f.Flag('+') {
+			for _, fr := range te.resolve() {
+				fmt.Fprintf(f, "\n\t%s:%d %s", fr.File, fr.Line, fr.Function)
+			}
+		}
+	default:
+		io.WriteString(f, te.Error())
+	}
+}
+
+// Format implements fmt.Formatter for ErrorBag. "%v" and "%s" render the
+// same compact message as Error(); "%+v" instead prints each contained
+// error on its own line followed by its stack trace, when one was captured.
+// "%#v" uses eb's GoString method when it has one and otherwise prints the
+// same as "%v".
+func (eb *ErrorBag) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if f.Flag('#') {
+			if gs, ok := interface{}(eb).(fmt.GoStringer); ok {
+				io.WriteString(f, gs.GoString())
+				return
+			}
+		}
+		if f.Flag('+') {
+			for i, err := range eb.errs {
+				if i > 0 {
+					io.WriteString(f, "\n")
+				}
+				fmt.Fprintf(f, "%+v", err)
+			}
+			return
+		}
+		io.WriteString(f, eb.Error())
+	default:
+		io.WriteString(f, eb.Error())
+	}
+}
+
+// Frames returns the stack frames captured for err, if any. If err was not
+// collected while stack-trace capture was enabled, Frames returns nil.
+func Frames(err error) []runtime.Frame {
+	var te *tracedError
+	if errors.As(err, &te) {
+		return te.resolve()
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+// Copyright © 2018 Timothy E. Peoples <eng@toolman.org>
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+package errbag
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestWithStackTrace(t *testing.T) {
+	eb := WithStackTrace()
+	eb.Errorf("boom")
+
+	if got := fmt.Sprintf("%v", eb); got != "boom" {
+		t.Errorf("%%v := %q; wanted %q", got, "boom")
+	}
+
+	got := fmt.Sprintf("%+v", eb)
+	if !strings.HasPrefix(got, "boom\n\t") {
+		t.Errorf("%%+v := %q; wanted a stack trace after the message", got)
+	}
+
+	if frames := Frames(eb.errs[0]); len(frames) == 0 {
+		t.Error("Frames(eb.errs[0]) := empty; wanted at least one frame")
+	}
+}
+
+func TestTraceOnAddDisabledByDefault(t *testing.T) {
+	eb := new(ErrorBag)
+	eb.Errorf("boom")
+
+	if got := fmt.Sprintf("%+v", eb); got != "boom" {
+		t.Errorf("%%+v := %q; wanted %q (no trace captured)", got, "boom")
+	}
+
+	if frames := Frames(eb.errs[0]); frames != nil {
+		t.Errorf("Frames(eb.errs[0]) := %v; wanted nil", frames)
+	}
+}
+
+func TestTraceOnAddSeesThroughUnwrap(t *testing.T) {
+	eb := WithStackTrace()
+	target := errors.New("target")
+	eb.Add(fmt.Errorf("wrapping: %w", target))
+
+	if !errors.Is(eb, target) {
+		t.Error("errors.Is(eb, target) := false; wanted true through a traced, wrapped error")
+	}
+}